@@ -4,13 +4,21 @@ import (
 	"flag"
 	"fmt"
 	"os"
+	"path/filepath"
 
 	wakeonlan "github.com/johndo100/wakeonlan/pkg/magic"
 )
 
 func main() {
+	if len(os.Args) > 1 && os.Args[1] == "list" {
+		runList(os.Args[2:])
+		return
+	}
+
 	// Define command-line flags
-	macAddr := flag.String("mac", "", "Target MAC address (required). Format: XX:XX:XX:XX:XX:XX or XX-XX-XX-XX-XX-XX")
+	macAddr := flag.String("mac", "", "Target MAC address. Format: XX:XX:XX:XX:XX:XX or XX-XX-XX-XX-XX-XX")
+	name := flag.String("name", "", "Named device to wake, resolved from -config (alternative to -mac)")
+	configPath := flag.String("config", defaultConfigPath(), "Path to the device registry file (.json, .yaml, or .yml)")
 	ip := flag.String("ip", "255.255.255.255", "Broadcast IP address (default: 255.255.255.255)")
 	port := flag.String("port", "9", "Destination port: 0 (any), 7 (echo), or 9 (discard, default)")
 	helpFlag := flag.Bool("help", false, "Show this help message")
@@ -23,9 +31,14 @@ func main() {
 		os.Exit(0)
 	}
 
+	if *name != "" {
+		wakeByName(*name, *configPath)
+		return
+	}
+
 	// Validate required MAC address
 	if *macAddr == "" {
-		fmt.Fprintf(os.Stderr, "Error: MAC address is required\n\n")
+		fmt.Fprintf(os.Stderr, "Error: -mac or -name is required\n\n")
 		printHelp()
 		os.Exit(1)
 	}
@@ -46,11 +59,70 @@ func main() {
 	fmt.Println("✓ Magic packet sent successfully")
 }
 
+// wakeByName loads the registry at configPath and wakes the named device.
+func wakeByName(name, configPath string) {
+	reg, err := wakeonlan.LoadRegistry(configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Printf("Waking %q using %s...\n", name, configPath)
+
+	if err := reg.Wake(name); err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	fmt.Println("✓ Magic packet sent successfully")
+}
+
+// runList handles the "wakeonlan list" subcommand, printing every device
+// known to the registry.
+func runList(args []string) {
+	fs := flag.NewFlagSet("list", flag.ExitOnError)
+	configPath := fs.String("config", defaultConfigPath(), "Path to the device registry file (.json, .yaml, or .yml)")
+	fs.Parse(args)
+
+	reg, err := wakeonlan.LoadRegistry(*configPath)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		os.Exit(1)
+	}
+
+	names := reg.Names()
+	if len(names) == 0 {
+		fmt.Printf("No devices in %s\n", *configPath)
+		return
+	}
+
+	for _, name := range names {
+		d, _ := reg.Device(name)
+		fmt.Printf("%s\t%s\n", name, d.MAC)
+	}
+}
+
+// defaultConfigPath returns $XDG_CONFIG_HOME/wakeonlan/hosts.yaml, falling
+// back to $HOME/.config/wakeonlan/hosts.yaml if XDG_CONFIG_HOME is unset.
+func defaultConfigPath() string {
+	if dir := os.Getenv("XDG_CONFIG_HOME"); dir != "" {
+		return filepath.Join(dir, "wakeonlan", "hosts.yaml")
+	}
+
+	home, err := os.UserHomeDir()
+	if err != nil {
+		return ""
+	}
+	return filepath.Join(home, ".config", "wakeonlan", "hosts.yaml")
+}
+
 func printHelp() {
 	fmt.Fprintf(os.Stderr, `Wake-on-LAN Magic Packet Sender
 
 Usage:
   wakeonlan -mac <address> [options]
+  wakeonlan -name <device> [options]
+  wakeonlan list [-config <path>]
 
 Examples:
   # Send to a specific MAC address using default broadcast
@@ -62,6 +134,12 @@ Examples:
   # Use dash-separated MAC format
   wakeonlan -mac 00-11-22-33-44-55 -ip 192.168.1.100
 
+  # Wake a named device from the registry
+  wakeonlan -name workstation
+
+  # List known devices
+  wakeonlan list
+
 Options:
 `)
 	flag.PrintDefaults()
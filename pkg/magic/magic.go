@@ -29,16 +29,37 @@ package wakeonlan
 
 import (
 	"bytes"
+	"errors"
 	"fmt"
 	"net"
 	"strconv"
+	"sync"
 )
 
 // Packet represents a Wake-on-LAN magic packet that can be constructed and sent.
 // Use SendMagic as a convenience function, or construct and populate a Packet
 // manually using WriteMAC followed by SendUDP for more control.
 type Packet struct {
-	payload bytes.Buffer
+	payload   bytes.Buffer
+	transport Transport
+}
+
+// NewPacketWithTransport returns an empty Packet that sends via t instead of
+// the default UDP4 transport. Use this to inject a MemoryTransport in tests.
+// t is driven through SendUDP (dest is a "host:port" pair) unless t addresses
+// by MAC instead of IP (e.g. RawEthernetTransport), in which case use
+// SendRawEthernet instead.
+func NewPacketWithTransport(t Transport) *Packet {
+	return &Packet{transport: t}
+}
+
+// transportOrDefault returns p.transport, falling back to the default UDP4
+// transport when none has been set.
+func (p *Packet) transportOrDefault() Transport {
+	if p.transport == nil {
+		return udp4Transport{}
+	}
+	return p.transport
 }
 
 // writeHeader writes 6 bytes of 0xFF to the packet payload.
@@ -84,12 +105,32 @@ func (p *Packet) WriteMAC(addr string) error {
 	return nil
 }
 
-// optional SecureON (tm) password
-// implement passwd method to append
-// more [6]byte in the end of the payload
-// func (p *Packet) passwd(p string) {
+// WritePassword appends a 6-byte SecureON (tm) password to the packet payload.
+//
+// The password must be in the same colon/dash-separated hex form accepted by
+// net.ParseMAC (e.g. "AA:BB:CC:DD:EE:FF"). WritePassword should be called
+// after WriteMAC; the resulting payload is 108 bytes (6-byte header + 16 MAC
+// repetitions + 6-byte password).
+//
+// Parameters:
+//   - pwd: SecureON password, parsed the same way as a MAC address.
+//
+// Returns:
+//   - nil on success
+//   - error if pwd does not parse to exactly 6 bytes
+func (p *Packet) WritePassword(pwd string) error {
+	hwAddr, err := net.ParseMAC(pwd)
+	if err != nil {
+		return fmt.Errorf("invalid SecureON password %q: %w", pwd, err)
+	}
 
-// }
+	if len(hwAddr) != 6 {
+		return fmt.Errorf("invalid SecureON password %q: want 6 bytes, got %d", pwd, len(hwAddr))
+	}
+
+	p.payload.Write(hwAddr)
+	return nil
+}
 
 // SendUDP sends the constructed magic packet via UDP to the target address and port.
 //
@@ -119,9 +160,142 @@ func (p *Packet) WriteMAC(addr string) error {
 //	    log.Fatal(err)
 //	}
 func (p *Packet) SendUDP(ip, port string) error {
-	// SUPPORT IPV4 ONLY
-	const network = "udp4"
+	remoteIP, err := getRAddr(ip)
+	if err != nil {
+		return fmt.Errorf("parse remote IP: %w", err)
+	}
+
+	remotePort, err := getPort(port)
+	if err != nil {
+		return fmt.Errorf("parse port: %w", err)
+	}
+
+	dest := net.JoinHostPort(remoteIP.String(), strconv.Itoa(remotePort))
+	if err := p.transportOrDefault().Send(p.payload.Bytes(), dest); err != nil {
+		return fmt.Errorf("send packet: %w", err)
+	}
+
+	return nil
+}
+
+// SendRawEthernet sends the constructed packet through the Packet's
+// configured Transport, addressed by the destination MAC address destMAC
+// instead of an IP:port pair. Use this with a MAC-addressed Transport such
+// as RawEthernetTransport; it is not meaningful with the default UDP4
+// transport (use SendUDP for that).
+//
+// Parameters:
+//   - destMAC: Destination MAC address for the raw frame (e.g. the target's
+//     MAC, or "ff:ff:ff:ff:ff:ff" to broadcast).
+//
+// Returns:
+//   - nil on successful transmission
+//   - error if destMAC is invalid or the transport's send fails
+func (p *Packet) SendRawEthernet(destMAC string) error {
+	if _, err := net.ParseMAC(destMAC); err != nil {
+		return fmt.Errorf("invalid destination MAC %q: %w", destMAC, err)
+	}
 
+	if err := p.transportOrDefault().Send(p.payload.Bytes(), destMAC); err != nil {
+		return fmt.Errorf("send raw Ethernet frame: %w", err)
+	}
+
+	return nil
+}
+
+// SendUDPAll broadcasts the constructed magic packet out of every eligible
+// local network interface, in addition to the global broadcast address.
+//
+// On multi-homed hosts, sending to a single destination IP (as SendUDP does)
+// often leaves via the wrong NIC and never reaches the target's L2 segment.
+// SendUDPAll enumerates local interfaces, computes the directed broadcast
+// address for each IPv4 address found, and sends the packet from that
+// interface's source IP. It also sends to 255.255.255.255 as a fallback.
+//
+// SendUDPAll always dials real UDP4 sockets directly; it does not go through
+// the Packet's configured Transport, since binding each send to a specific
+// interface's source IP isn't expressible through the generic Transport
+// interface. A Transport installed via NewPacketWithTransport has no effect
+// on this method.
+//
+// Parameters:
+//   - port: Destination port as a string. Accepted values: "0", "7", "9" (default).
+//
+// Returns:
+//   - nil if at least one send succeeded
+//   - a combined error (via errors.Join) if every send failed
+func (p *Packet) SendUDPAll(port string) error {
+	remotePort, err := getPort(port)
+	if err != nil {
+		return fmt.Errorf("parse port: %w", err)
+	}
+
+	destinations := broadcastAddrs()
+
+	var (
+		mu      sync.Mutex
+		errs    []error
+		success bool
+		wg      sync.WaitGroup
+	)
+
+	send := func(srcIP, dstIP net.IP) {
+		defer wg.Done()
+
+		raddr := net.UDPAddr{IP: dstIP, Port: remotePort}
+		var laddr *net.UDPAddr
+		if srcIP != nil {
+			laddr = &net.UDPAddr{IP: srcIP}
+		}
+
+		conn, err := net.DialUDP("udp4", laddr, &raddr)
+		if err != nil {
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("dial UDP via %v to %v: %w", srcIP, dstIP, err))
+			mu.Unlock()
+			return
+		}
+		defer conn.Close()
+
+		if _, err := conn.Write(p.payload.Bytes()); err != nil {
+			mu.Lock()
+			errs = append(errs, fmt.Errorf("write UDP packet via %v to %v: %w", srcIP, dstIP, err))
+			mu.Unlock()
+			return
+		}
+
+		mu.Lock()
+		success = true
+		mu.Unlock()
+	}
+
+	for _, dest := range destinations {
+		wg.Add(1)
+		go send(dest.srcIP, dest.dstIP)
+	}
+	wg.Wait()
+
+	if success {
+		return nil
+	}
+	return fmt.Errorf("send magic packet to all interfaces: %w", errors.Join(errs...))
+}
+
+// SendUDPFrom sends the constructed packet to ip:port, binding to the named
+// local interface's first IPv4 address as the source. Like SendUDPAll, this
+// bypasses the Packet's configured Transport and dials a real UDP4 socket
+// directly, since per-interface source binding isn't expressible through the
+// generic Transport interface.
+//
+// Parameters:
+//   - iface: Name of the local interface to bind as the source (e.g. "eth0").
+//   - ip: Destination IP address, typically a broadcast address.
+//   - port: Destination port as a string. Accepted values: "0", "7", "9" (default).
+//
+// Returns:
+//   - nil on successful transmission
+//   - error if iface has no IPv4 address, or the send fails
+func (p *Packet) SendUDPFrom(iface, ip, port string) error {
 	remoteIP, err := getRAddr(ip)
 	if err != nil {
 		return fmt.Errorf("parse remote IP: %w", err)
@@ -132,25 +306,114 @@ func (p *Packet) SendUDP(ip, port string) error {
 		return fmt.Errorf("parse port: %w", err)
 	}
 
-	raddr := net.UDPAddr{
-		IP:   remoteIP,
-		Port: remotePort,
+	srcIP, err := interfaceIPv4(iface)
+	if err != nil {
+		return fmt.Errorf("resolve interface %q: %w", iface, err)
 	}
 
-	conn, err := net.DialUDP(network, nil, &raddr)
+	raddr := net.UDPAddr{IP: remoteIP, Port: remotePort}
+	laddr := net.UDPAddr{IP: srcIP}
+
+	conn, err := net.DialUDP("udp4", &laddr, &raddr)
 	if err != nil {
-		return fmt.Errorf("dial UDP: %w", err)
+		return fmt.Errorf("dial UDP via %q: %w", iface, err)
 	}
 	defer conn.Close()
 
-	_, err = conn.Write(p.payload.Bytes())
-	if err != nil {
-		return fmt.Errorf("write UDP packet: %w", err)
+	if _, err := conn.Write(p.payload.Bytes()); err != nil {
+		return fmt.Errorf("write UDP packet via %q: %w", iface, err)
 	}
 
 	return nil
 }
 
+// interfaceIPv4 returns the first IPv4 address configured on the named local
+// interface.
+func interfaceIPv4(name string) (net.IP, error) {
+	iface, err := net.InterfaceByName(name)
+	if err != nil {
+		return nil, err
+	}
+
+	addrs, err := iface.Addrs()
+	if err != nil {
+		return nil, err
+	}
+
+	for _, addr := range addrs {
+		ipNet, ok := addr.(*net.IPNet)
+		if !ok {
+			continue
+		}
+		if ip4 := ipNet.IP.To4(); ip4 != nil {
+			return ip4, nil
+		}
+	}
+
+	return nil, fmt.Errorf("interface %q has no IPv4 address", name)
+}
+
+// broadcastDest pairs a source IP (the interface address to bind to, or nil
+// to let the OS choose) with the destination broadcast address to send to.
+type broadcastDest struct {
+	srcIP net.IP
+	dstIP net.IP
+}
+
+// broadcastAddrs enumerates local interfaces eligible for broadcast (up,
+// broadcast-capable, non-loopback) and returns the directed broadcast
+// address for each IPv4 address found, plus the global broadcast address
+// 255.255.255.255 as a fallback.
+func broadcastAddrs() []broadcastDest {
+	dests := []broadcastDest{{srcIP: nil, dstIP: net.IPv4(255, 255, 255, 255)}}
+
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		return dests
+	}
+
+	const want = net.FlagUp | net.FlagBroadcast
+	for _, iface := range ifaces {
+		if iface.Flags&want != want || iface.Flags&net.FlagLoopback != 0 {
+			continue
+		}
+
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+
+		for _, addr := range addrs {
+			ipNet, ok := addr.(*net.IPNet)
+			if !ok {
+				continue
+			}
+
+			ip4 := ipNet.IP.To4()
+			if ip4 == nil {
+				continue
+			}
+
+			dests = append(dests, broadcastDest{
+				srcIP: ip4,
+				dstIP: directedBroadcast(ip4, ipNet.Mask),
+			})
+		}
+	}
+
+	return dests
+}
+
+// directedBroadcast computes the directed broadcast address for ip/mask by
+// setting every host bit to 1.
+func directedBroadcast(ip net.IP, mask net.IPMask) net.IP {
+	bcast := make(net.IP, len(ip))
+	for i := range ip {
+		bcast[i] = ip[i] | ^mask[i]
+	}
+	return bcast
+}
+
 // getRAddr parses the remote IP address.
 // If addr is empty, returns the broadcast address (255.255.255.255).
 // Returns an error if the address is invalid (non-empty and unparseable).
@@ -202,7 +465,8 @@ func getPort(port string) (int, error) {
 // Parameters:
 //   - macAddr: Target MAC address of the computer to wake (required).
 //     Format: "XX:XX:XX:XX:XX:XX" or "XX-XX-XX-XX-XX-XX"
-//   - passwd: SecureON password (currently unsupported, pass empty string "").
+//   - passwd: Optional SecureON password, in the same format as macAddr.
+//     Pass an empty string to send an unprotected magic packet.
 //   - ip: Destination IP address, typically a broadcast address (e.g., "255.255.255.255").
 //     If empty string is passed, defaults to broadcast address.
 //   - port: Destination port as a string. Common values: "9" (discard), "7" (echo), "0" (any).
@@ -232,26 +496,80 @@ func getPort(port string) (int, error) {
 //	    log.Fatal(err)
 //	}
 func SendMagic(macAddr, passwd, ip, port string) error {
-	pk := new(Packet)
-
-	// without password
-	if passwd == "" {
-		// assemble magic header
-		pk.writeHeader()
-		// write the MAC address 16 times
-		err := pk.WriteMAC(macAddr)
-		if err != nil {
-			return fmt.Errorf("write MAC address: %w", err)
-		}
-	} else {
-		// with password
-		return fmt.Errorf("password-protected Wake-on-LAN not supported yet")
+	pk, err := buildPacket(macAddr, passwd)
+	if err != nil {
+		return err
 	}
 
 	// send via UDP4
-	err := pk.SendUDP(ip, port)
-	if err != nil {
+	if err := pk.SendUDP(ip, port); err != nil {
 		return fmt.Errorf("send UDP: %w", err)
 	}
 	return nil
 }
+
+// SendMagicAll builds a magic packet and broadcasts it out of every eligible
+// local network interface via Packet.SendUDPAll. Use this on multi-homed
+// hosts where SendMagic's single destination IP may go out the wrong NIC.
+//
+// Parameters are the same as SendMagic, minus the destination IP (SendUDPAll
+// determines destinations itself).
+//
+// Returns:
+//   - nil if the packet was delivered on at least one interface
+//   - error describing what went wrong (invalid MAC/password, or every send failed)
+func SendMagicAll(macAddr, passwd, port string) error {
+	pk, err := buildPacket(macAddr, passwd)
+	if err != nil {
+		return err
+	}
+
+	if err := pk.SendUDPAll(port); err != nil {
+		return fmt.Errorf("send UDP to all interfaces: %w", err)
+	}
+	return nil
+}
+
+// SendMagicFrom builds a magic packet and sends it via Packet.SendUDPFrom,
+// binding the send to the named local interface's source IP. Use this when a
+// target device is only reachable from a specific NIC.
+//
+// Parameters are the same as SendMagic, with iface naming the local
+// interface to bind as the source.
+//
+// Returns:
+//   - nil on successful transmission
+//   - error describing what went wrong (invalid MAC/password, unknown
+//     interface, or network error)
+func SendMagicFrom(macAddr, passwd, ip, port, iface string) error {
+	pk, err := buildPacket(macAddr, passwd)
+	if err != nil {
+		return err
+	}
+
+	if err := pk.SendUDPFrom(iface, ip, port); err != nil {
+		return fmt.Errorf("send UDP from %q: %w", iface, err)
+	}
+	return nil
+}
+
+// buildPacket assembles a magic packet header, MAC address, and optional
+// SecureON password, shared by SendMagic and SendMagicAll.
+func buildPacket(macAddr, passwd string) (*Packet, error) {
+	pk := new(Packet)
+
+	// assemble magic header
+	pk.writeHeader()
+	// write the MAC address 16 times
+	if err := pk.WriteMAC(macAddr); err != nil {
+		return nil, fmt.Errorf("write MAC address: %w", err)
+	}
+
+	if passwd != "" {
+		if err := pk.WritePassword(passwd); err != nil {
+			return nil, fmt.Errorf("write SecureON password: %w", err)
+		}
+	}
+
+	return pk, nil
+}
@@ -2,6 +2,7 @@ package wakeonlan
 
 import (
 "bytes"
+"net"
 "testing"
 )
 
@@ -218,7 +219,7 @@ passwd  string
 wantErr bool
 }{
 {
-name:    "password not supported",
+name:    "invalid password format",
 macAddr: "00:11:22:33:44:55",
 passwd:  "password",
 wantErr: true,
@@ -373,6 +374,233 @@ t.Errorf("getPort(\"\") = %d, want 9 (default)", got)
 }
 }
 
+// TestWritePassword tests the WritePassword method with various inputs.
+func TestWritePassword(t *testing.T) {
+tests := []struct {
+name    string
+passwd  string
+wantErr bool
+}{
+{
+name:    "valid password with colons",
+passwd:  "AA:BB:CC:DD:EE:FF",
+wantErr: false,
+},
+{
+name:    "valid password with dashes",
+passwd:  "aa-bb-cc-dd-ee-ff",
+wantErr: false,
+},
+{
+name:    "wrong length - too short",
+passwd:  "AA:BB:CC:DD",
+wantErr: true,
+},
+{
+name:    "invalid hex",
+passwd:  "not-a-password",
+wantErr: true,
+},
+}
+
+for _, tt := range tests {
+t.Run(tt.name, func(t *testing.T) {
+p := &Packet{}
+p.writeHeader()
+if err := p.WriteMAC("00:11:22:33:44:55"); err != nil {
+t.Fatalf("WriteMAC() unexpected error: %v", err)
+}
+
+err := p.WritePassword(tt.passwd)
+if (err != nil) != tt.wantErr {
+t.Errorf("WritePassword() error = %v, wantErr %v", err, tt.wantErr)
+return
+}
+
+if !tt.wantErr && p.payload.Len() != 108 {
+t.Errorf("WritePassword() payload size = %d, want 108", p.payload.Len())
+}
+})
+}
+}
+
+// TestWritePasswordPayloadContent verifies the password bytes land at offsets 102-107.
+func TestWritePasswordPayloadContent(t *testing.T) {
+p := &Packet{}
+p.writeHeader()
+if err := p.WriteMAC("00:11:22:33:44:55"); err != nil {
+t.Fatalf("WriteMAC() unexpected error: %v", err)
+}
+if err := p.WritePassword("AA:BB:CC:DD:EE:FF"); err != nil {
+t.Fatalf("WritePassword() unexpected error: %v", err)
+}
+
+payload := p.payload.Bytes()
+if len(payload) != 108 {
+t.Fatalf("payload size = %d, want 108", len(payload))
+}
+
+expected := []byte{0xaa, 0xbb, 0xcc, 0xdd, 0xee, 0xff}
+for i, b := range expected {
+if payload[102+i] != b {
+t.Errorf("password byte at offset %d = 0x%02X, want 0x%02X", 102+i, payload[102+i], b)
+}
+}
+}
+
+// TestSendMagicWithPassword verifies SendMagic accepts a valid SecureON password.
+func TestSendMagicWithPassword(t *testing.T) {
+tests := []struct {
+name    string
+passwd  string
+wantErr bool
+}{
+{
+name:    "valid password no longer errors on parsing",
+passwd:  "AA:BB:CC:DD:EE:FF",
+wantErr: false,
+},
+{
+name:    "invalid password format",
+passwd:  "not-a-password",
+wantErr: true,
+},
+}
+
+for _, tt := range tests {
+t.Run(tt.name, func(t *testing.T) {
+err := SendMagic("00:11:22:33:44:55", tt.passwd, "255.255.255.255", "9")
+if (err != nil) != tt.wantErr {
+t.Errorf("SendMagic() error = %v, wantErr %v", err, tt.wantErr)
+}
+})
+}
+}
+
+// TestDirectedBroadcast verifies directed broadcast addresses are computed
+// correctly from an IP and subnet mask.
+func TestDirectedBroadcast(t *testing.T) {
+tests := []struct {
+name string
+ip   string
+mask net.IPMask
+want string
+}{
+{
+name: "/24 network",
+ip:   "192.168.1.100",
+mask: net.CIDRMask(24, 32),
+want: "192.168.1.255",
+},
+{
+name: "/16 network",
+ip:   "10.0.5.20",
+mask: net.CIDRMask(16, 32),
+want: "10.0.255.255",
+},
+{
+name: "/32 host route",
+ip:   "172.16.0.1",
+mask: net.CIDRMask(32, 32),
+want: "172.16.0.1",
+},
+}
+
+for _, tt := range tests {
+t.Run(tt.name, func(t *testing.T) {
+got := directedBroadcast(net.ParseIP(tt.ip).To4(), tt.mask)
+if got.String() != tt.want {
+t.Errorf("directedBroadcast(%q) = %v, want %v", tt.ip, got, tt.want)
+}
+})
+}
+}
+
+// TestBroadcastAddrsIncludesFallback verifies the global broadcast address
+// is always present regardless of the local interface set.
+func TestBroadcastAddrsIncludesFallback(t *testing.T) {
+dests := broadcastAddrs()
+
+found := false
+for _, d := range dests {
+if d.srcIP == nil && d.dstIP.String() == "255.255.255.255" {
+found = true
+break
+}
+}
+
+if !found {
+t.Errorf("broadcastAddrs() missing global broadcast fallback, got %+v", dests)
+}
+}
+
+// TestInterfaceIPv4 verifies interfaceIPv4 resolves a real local interface's
+// IPv4 address, and rejects an interface name that doesn't exist.
+func TestInterfaceIPv4(t *testing.T) {
+	ifaces, err := net.Interfaces()
+	if err != nil {
+		t.Skipf("net.Interfaces() unavailable: %v", err)
+	}
+
+	var found bool
+	for _, iface := range ifaces {
+		addrs, err := iface.Addrs()
+		if err != nil {
+			continue
+		}
+		hasIPv4 := false
+		for _, addr := range addrs {
+			if ipNet, ok := addr.(*net.IPNet); ok && ipNet.IP.To4() != nil {
+				hasIPv4 = true
+				break
+			}
+		}
+		if !hasIPv4 {
+			continue
+		}
+
+		ip, err := interfaceIPv4(iface.Name)
+		if err != nil {
+			t.Errorf("interfaceIPv4(%q) unexpected error: %v", iface.Name, err)
+			continue
+		}
+		if ip.To4() == nil {
+			t.Errorf("interfaceIPv4(%q) = %v, want an IPv4 address", iface.Name, ip)
+		}
+		found = true
+	}
+
+	if !found {
+		t.Skip("no local interface with an IPv4 address available in this environment")
+	}
+
+	if _, err := interfaceIPv4("no-such-interface"); err == nil {
+		t.Error("interfaceIPv4() expected error for unknown interface, got nil")
+	}
+}
+
+// TestSendUDPFrom verifies SendUDPFrom binds to a real local interface and
+// rejects an unknown one.
+func TestSendUDPFrom(t *testing.T) {
+	if _, err := interfaceIPv4("lo"); err != nil {
+		t.Skipf("loopback interface unavailable: %v", err)
+	}
+
+	p := &Packet{}
+	p.writeHeader()
+	if err := p.WriteMAC("00:11:22:33:44:55"); err != nil {
+		t.Fatalf("WriteMAC() unexpected error: %v", err)
+	}
+
+	if err := p.SendUDPFrom("lo", "127.0.0.1", "9"); err != nil {
+		t.Errorf("SendUDPFrom(\"lo\", ...) unexpected error: %v", err)
+	}
+
+	if err := p.SendUDPFrom("no-such-interface", "127.0.0.1", "9"); err == nil {
+		t.Error("SendUDPFrom() expected error for unknown interface, got nil")
+	}
+}
+
 // TestErrorWrapping verifies errors maintain context through wrapping.
 func TestErrorWrapping(t *testing.T) {
 err := SendMagic("invalid-mac", "", "255.255.255.255", "9")
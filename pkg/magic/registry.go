@@ -0,0 +1,172 @@
+package wakeonlan
+
+import (
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+)
+
+// Device is a named Wake-on-LAN target stored in a Registry. Only Name and
+// MAC are required; IP, Port, and Password fall back to SendMagic's defaults
+// (broadcast address, port 9, no password) when left empty. Interface, if
+// set, binds the send to that local interface via SendMagicFrom instead of
+// letting the OS pick a route.
+type Device struct {
+	Name      string `json:"name" yaml:"name"`
+	MAC       string `json:"mac" yaml:"mac"`
+	IP        string `json:"ip,omitempty" yaml:"ip,omitempty"`
+	Port      string `json:"port,omitempty" yaml:"port,omitempty"`
+	Password  string `json:"password,omitempty" yaml:"password,omitempty"`
+	Interface string `json:"interface,omitempty" yaml:"interface,omitempty"`
+}
+
+// Registry is a collection of named devices, keyed by Device.Name, loaded
+// from a config file via LoadRegistry.
+type Registry struct {
+	devices map[string]Device
+}
+
+// LoadRegistry reads a YAML or JSON file of devices (selected by the path's
+// extension: .json, .yaml, or .yml) and returns the resulting Registry.
+//
+// Each entry must have at least a name and a mac. See Device for the full
+// set of recognized fields.
+func LoadRegistry(path string) (*Registry, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("read registry %q: %w", path, err)
+	}
+
+	var devices []Device
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".json":
+		if err := json.Unmarshal(data, &devices); err != nil {
+			return nil, fmt.Errorf("parse registry %q as JSON: %w", path, err)
+		}
+	case ".yaml", ".yml":
+		devices, err = parseYAMLDevices(data)
+		if err != nil {
+			return nil, fmt.Errorf("parse registry %q as YAML: %w", path, err)
+		}
+	default:
+		return nil, fmt.Errorf("unsupported registry file extension %q (use .json, .yaml, or .yml)", ext)
+	}
+
+	reg := &Registry{devices: make(map[string]Device, len(devices))}
+	for _, d := range devices {
+		if d.Name == "" {
+			return nil, fmt.Errorf("registry %q: device missing name", path)
+		}
+		if d.MAC == "" {
+			return nil, fmt.Errorf("registry %q: device %q missing mac", path, d.Name)
+		}
+		reg.devices[d.Name] = d
+	}
+	return reg, nil
+}
+
+// Wake resolves name in the registry and sends it a magic packet using the
+// device's stored MAC, password, IP, and port. If the device has an
+// Interface set, the send is bound to that local interface via
+// SendMagicFrom; otherwise it goes through plain SendMagic.
+func (r *Registry) Wake(name string) error {
+	d, ok := r.devices[name]
+	if !ok {
+		return fmt.Errorf("unknown device %q", name)
+	}
+
+	var err error
+	if d.Interface != "" {
+		err = SendMagicFrom(d.MAC, d.Password, d.IP, d.Port, d.Interface)
+	} else {
+		err = SendMagic(d.MAC, d.Password, d.IP, d.Port)
+	}
+
+	if err != nil {
+		return fmt.Errorf("wake %q: %w", name, err)
+	}
+	return nil
+}
+
+// Names returns the registry's device names in sorted order, for listing.
+func (r *Registry) Names() []string {
+	names := make([]string, 0, len(r.devices))
+	for name := range r.devices {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Device returns the named device and whether it was found, for callers
+// that want to display its details (e.g. the "list" subcommand).
+func (r *Registry) Device(name string) (Device, bool) {
+	d, ok := r.devices[name]
+	return d, ok
+}
+
+// parseYAMLDevices parses a minimal YAML subset: a block sequence of flat
+// maps, one per device, e.g.:
+//
+//	- name: workstation
+//	  mac: AA:BB:CC:DD:EE:FF
+//	  ip: 192.168.1.255
+//
+// This is intentionally not a general-purpose YAML parser; it only
+// understands the Device schema above, which keeps the registry free of an
+// external YAML dependency.
+func parseYAMLDevices(data []byte) ([]Device, error) {
+	var devices []Device
+	var cur *Device
+
+	for i, raw := range strings.Split(string(data), "\n") {
+		line := strings.TrimRight(raw, " \t\r")
+		trimmed := strings.TrimSpace(line)
+		if trimmed == "" || strings.HasPrefix(trimmed, "#") {
+			continue
+		}
+
+		if strings.HasPrefix(trimmed, "- ") {
+			if cur != nil {
+				devices = append(devices, *cur)
+			}
+			cur = &Device{}
+			trimmed = strings.TrimPrefix(trimmed, "- ")
+		}
+		if cur == nil {
+			return nil, fmt.Errorf("line %d: expected a list item (\"- name: ...\"), got %q", i+1, line)
+		}
+
+		key, value, ok := strings.Cut(trimmed, ":")
+		if !ok {
+			return nil, fmt.Errorf("line %d: expected \"key: value\", got %q", i+1, line)
+		}
+		key = strings.TrimSpace(key)
+		value = strings.Trim(strings.TrimSpace(value), `"'`)
+
+		switch key {
+		case "name":
+			cur.Name = value
+		case "mac":
+			cur.MAC = value
+		case "ip":
+			cur.IP = value
+		case "port":
+			cur.Port = value
+		case "password":
+			cur.Password = value
+		case "interface":
+			cur.Interface = value
+		default:
+			return nil, fmt.Errorf("line %d: unknown device field %q", i+1, key)
+		}
+	}
+	if cur != nil {
+		devices = append(devices, *cur)
+	}
+
+	return devices, nil
+}
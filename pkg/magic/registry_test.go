@@ -0,0 +1,163 @@
+package wakeonlan
+
+import (
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// TestLoadRegistryJSON tests loading a registry from a JSON file.
+func TestLoadRegistryJSON(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.json")
+	writeFile(t, path, `[
+		{"name": "workstation", "mac": "AA:BB:CC:DD:EE:FF", "ip": "192.168.1.255"},
+		{"name": "nas", "mac": "00:11:22:33:44:55"}
+	]`)
+
+	reg, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() unexpected error: %v", err)
+	}
+
+	if got, want := reg.Names(), []string{"nas", "workstation"}; !equalStrings(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	d, ok := reg.Device("workstation")
+	if !ok {
+		t.Fatal("Device(\"workstation\") not found")
+	}
+	if d.MAC != "AA:BB:CC:DD:EE:FF" || d.IP != "192.168.1.255" {
+		t.Errorf("Device(\"workstation\") = %+v, unexpected fields", d)
+	}
+}
+
+// TestLoadRegistryYAML tests loading a registry from the minimal supported
+// YAML subset.
+func TestLoadRegistryYAML(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.yaml")
+	writeFile(t, path, `
+- name: workstation
+  mac: AA:BB:CC:DD:EE:FF
+  ip: 192.168.1.255
+  port: "9"
+- name: nas
+  mac: 00:11:22:33:44:55
+  interface: eth0
+`)
+
+	reg, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() unexpected error: %v", err)
+	}
+
+	if got, want := reg.Names(), []string{"nas", "workstation"}; !equalStrings(got, want) {
+		t.Errorf("Names() = %v, want %v", got, want)
+	}
+
+	d, ok := reg.Device("nas")
+	if !ok {
+		t.Fatal("Device(\"nas\") not found")
+	}
+	if d.MAC != "00:11:22:33:44:55" || d.Interface != "eth0" {
+		t.Errorf("Device(\"nas\") = %+v, unexpected fields", d)
+	}
+}
+
+// TestLoadRegistryErrors tests error handling for malformed or missing
+// registries.
+func TestLoadRegistryErrors(t *testing.T) {
+	tests := []struct {
+		name    string
+		ext     string
+		content string
+	}{
+		{
+			name:    "missing name",
+			ext:     ".json",
+			content: `[{"mac": "AA:BB:CC:DD:EE:FF"}]`,
+		},
+		{
+			name:    "missing mac",
+			ext:     ".json",
+			content: `[{"name": "workstation"}]`,
+		},
+		{
+			name:    "invalid JSON",
+			ext:     ".json",
+			content: `not json`,
+		},
+		{
+			name:    "unsupported extension",
+			ext:     ".txt",
+			content: `irrelevant`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			path := filepath.Join(t.TempDir(), "hosts"+tt.ext)
+			writeFile(t, path, tt.content)
+
+			if _, err := LoadRegistry(path); err == nil {
+				t.Error("LoadRegistry() expected error, got nil")
+			}
+		})
+	}
+}
+
+// TestRegistryWakeUnknownDevice tests that Wake rejects an unknown name.
+func TestRegistryWakeUnknownDevice(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.json")
+	writeFile(t, path, `[{"name": "workstation", "mac": "AA:BB:CC:DD:EE:FF"}]`)
+
+	reg, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() unexpected error: %v", err)
+	}
+
+	if err := reg.Wake("does-not-exist"); err == nil {
+		t.Error("Wake() expected error for unknown device, got nil")
+	}
+}
+
+// TestRegistryWakeUsesDeviceInterface verifies a device with an Interface
+// set routes through SendMagicFrom (surfacing an unknown-interface error)
+// instead of silently ignoring the field like plain SendMagic would.
+func TestRegistryWakeUsesDeviceInterface(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "hosts.json")
+	writeFile(t, path, `[{"name": "workstation", "mac": "AA:BB:CC:DD:EE:FF", "interface": "no-such-interface"}]`)
+
+	reg, err := LoadRegistry(path)
+	if err != nil {
+		t.Fatalf("LoadRegistry() unexpected error: %v", err)
+	}
+
+	err = reg.Wake("workstation")
+	if err == nil {
+		t.Fatal("Wake() expected error for unknown interface, got nil")
+	}
+	if !strings.Contains(err.Error(), "no-such-interface") {
+		t.Errorf("Wake() error = %v, want it to mention the configured interface", err)
+	}
+}
+
+func writeFile(t *testing.T, path, content string) {
+	t.Helper()
+	if err := os.WriteFile(path, []byte(content), 0o644); err != nil {
+		t.Fatalf("write test file %q: %v", path, err)
+	}
+}
+
+func equalStrings(a, b []string) bool {
+	if len(a) != len(b) {
+		return false
+	}
+	for i := range a {
+		if a[i] != b[i] {
+			return false
+		}
+	}
+	return true
+}
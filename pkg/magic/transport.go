@@ -0,0 +1,83 @@
+package wakeonlan
+
+import (
+	"fmt"
+	"net"
+	"sync"
+)
+
+// Transport abstracts the final network write of a magic packet, letting
+// Packet's construction logic stay independent of how bytes actually reach
+// the wire. The default transport (used when a Packet is constructed with
+// &Packet{} or NewPacketWithTransport(nil)) sends over UDP4, preserving the
+// historical behavior of SendUDP.
+//
+// dest is transport-specific: for the default UDP4 transport it's a "host:port" pair; for
+// RawEthernetTransport it's the destination MAC address.
+type Transport interface {
+	Send(payload []byte, dest string) error
+}
+
+// udp4Transport is the default Transport, sending payload as a single UDP4
+// datagram to dest (a "host:port" pair).
+type udp4Transport struct{}
+
+// Send resolves dest as a UDP4 address and writes payload to it in a single
+// datagram.
+func (udp4Transport) Send(payload []byte, dest string) error {
+	raddr, err := net.ResolveUDPAddr("udp4", dest)
+	if err != nil {
+		return fmt.Errorf("resolve UDP address %q: %w", dest, err)
+	}
+
+	conn, err := net.DialUDP("udp4", nil, raddr)
+	if err != nil {
+		return fmt.Errorf("dial UDP: %w", err)
+	}
+	defer conn.Close()
+
+	if _, err := conn.Write(payload); err != nil {
+		return fmt.Errorf("write UDP packet: %w", err)
+	}
+
+	return nil
+}
+
+// SentPacket records a single payload handed to a MemoryTransport, along
+// with the destination it was addressed to.
+type SentPacket struct {
+	Payload []byte
+	Dest    string
+}
+
+// MemoryTransport is a Transport that records every packet it is asked to
+// send instead of touching the network. It exists for tests that want to
+// assert on packet bytes (e.g. the 102/108-byte magic packet layout) without
+// opening real sockets.
+type MemoryTransport struct {
+	mu   sync.Mutex
+	Sent []SentPacket
+}
+
+// Send records payload and dest. It never returns an error.
+func (t *MemoryTransport) Send(payload []byte, dest string) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	buf := make([]byte, len(payload))
+	copy(buf, payload)
+	t.Sent = append(t.Sent, SentPacket{Payload: buf, Dest: dest})
+	return nil
+}
+
+// Last returns the most recently sent packet, or the zero value if nothing
+// has been sent yet.
+func (t *MemoryTransport) Last() SentPacket {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if len(t.Sent) == 0 {
+		return SentPacket{}
+	}
+	return t.Sent[len(t.Sent)-1]
+}
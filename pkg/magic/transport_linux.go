@@ -0,0 +1,82 @@
+//go:build linux
+
+package wakeonlan
+
+import (
+	"fmt"
+	"net"
+	"syscall"
+)
+
+// etherTypeWOL is the Ethertype conventionally used for raw Wake-on-LAN
+// frames (as opposed to UDP-encapsulated ones).
+const etherTypeWOL = 0x0842
+
+// RawEthernetTransport sends a magic packet as the payload of a raw Ethernet
+// II frame (Ethertype 0x0842) via an AF_PACKET socket, bypassing IP and UDP
+// entirely. This wakes devices that have no IP address configured yet, at
+// the cost of only working on the local L2 segment and requiring Linux plus
+// the privileges needed to open a raw socket (typically CAP_NET_RAW).
+//
+// RawEthernetTransport addresses by MAC rather than "host:port", so drive it
+// through Packet.SendRawEthernet (not SendUDP or SendMagic, which always
+// pass an "ip:port" dest).
+type RawEthernetTransport struct {
+	// Iface is the name of the local interface to transmit on (e.g. "eth0").
+	Iface string
+}
+
+// NewRawEthernetTransport returns a RawEthernetTransport that transmits on
+// the named local interface.
+func NewRawEthernetTransport(iface string) *RawEthernetTransport {
+	return &RawEthernetTransport{Iface: iface}
+}
+
+// Send builds an Ethernet II frame addressed to the MAC address dest,
+// sourced from t.Iface's hardware address, carrying payload as its body, and
+// transmits it on t.Iface via AF_PACKET.
+func (t *RawEthernetTransport) Send(payload []byte, dest string) error {
+	destMAC, err := net.ParseMAC(dest)
+	if err != nil {
+		return fmt.Errorf("invalid destination MAC %q: %w", dest, err)
+	}
+
+	iface, err := net.InterfaceByName(t.Iface)
+	if err != nil {
+		return fmt.Errorf("lookup interface %q: %w", t.Iface, err)
+	}
+
+	fd, err := syscall.Socket(syscall.AF_PACKET, syscall.SOCK_RAW, int(htons(etherTypeWOL)))
+	if err != nil {
+		return fmt.Errorf("open AF_PACKET socket: %w", err)
+	}
+	defer syscall.Close(fd)
+
+	addr := syscall.SockaddrLinklayer{
+		Protocol: htons(etherTypeWOL),
+		Ifindex:  iface.Index,
+		Halen:    6,
+	}
+	copy(addr.Addr[:6], destMAC)
+
+	if err := syscall.Bind(fd, &addr); err != nil {
+		return fmt.Errorf("bind AF_PACKET socket to %q: %w", t.Iface, err)
+	}
+
+	frame := make([]byte, 0, 14+len(payload))
+	frame = append(frame, destMAC...)
+	frame = append(frame, iface.HardwareAddr...)
+	frame = append(frame, byte(etherTypeWOL>>8), byte(etherTypeWOL&0xff))
+	frame = append(frame, payload...)
+
+	if err := syscall.Sendto(fd, frame, 0, &addr); err != nil {
+		return fmt.Errorf("send raw Ethernet frame on %q: %w", t.Iface, err)
+	}
+
+	return nil
+}
+
+// htons converts a 16-bit value from host to network byte order.
+func htons(v uint16) uint16 {
+	return v<<8&0xff00 | v>>8
+}
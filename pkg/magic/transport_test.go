@@ -0,0 +1,134 @@
+package wakeonlan
+
+import "testing"
+
+// TestMemoryTransportRecordsSend verifies MemoryTransport records payload and
+// destination without touching the network.
+func TestMemoryTransportRecordsSend(t *testing.T) {
+	mem := &MemoryTransport{}
+
+	payload := []byte{0xFF, 0xFF, 0xFF}
+	if err := mem.Send(payload, "255.255.255.255:9"); err != nil {
+		t.Fatalf("Send() unexpected error: %v", err)
+	}
+
+	if len(mem.Sent) != 1 {
+		t.Fatalf("Sent length = %d, want 1", len(mem.Sent))
+	}
+
+	got := mem.Last()
+	if got.Dest != "255.255.255.255:9" {
+		t.Errorf("Dest = %q, want %q", got.Dest, "255.255.255.255:9")
+	}
+	if string(got.Payload) != string(payload) {
+		t.Errorf("Payload = %v, want %v", got.Payload, payload)
+	}
+}
+
+// TestSendMagicEndToEndWithMemoryTransport exercises the same
+// header/MAC/password construction SendMagic performs, but via a
+// MemoryTransport-backed Packet, so the full pipeline can be asserted on
+// without opening real sockets.
+func TestSendMagicEndToEndWithMemoryTransport(t *testing.T) {
+	tests := []struct {
+		name       string
+		macAddr    string
+		passwd     string
+		wantLength int
+	}{
+		{
+			name:       "without password",
+			macAddr:    "00:11:22:33:44:55",
+			passwd:     "",
+			wantLength: 102,
+		},
+		{
+			name:       "with password",
+			macAddr:    "00:11:22:33:44:55",
+			passwd:     "AA:BB:CC:DD:EE:FF",
+			wantLength: 108,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			mem := &MemoryTransport{}
+			pk, err := buildPacket(tt.macAddr, tt.passwd)
+			if err != nil {
+				t.Fatalf("buildPacket() unexpected error: %v", err)
+			}
+			pk.transport = mem
+
+			if err := pk.SendUDP("255.255.255.255", "9"); err != nil {
+				t.Fatalf("SendUDP() unexpected error: %v", err)
+			}
+
+			sent := mem.Last()
+			if len(sent.Payload) != tt.wantLength {
+				t.Errorf("payload length = %d, want %d", len(sent.Payload), tt.wantLength)
+			}
+			if sent.Dest != "255.255.255.255:9" {
+				t.Errorf("Dest = %q, want %q", sent.Dest, "255.255.255.255:9")
+			}
+		})
+	}
+}
+
+// TestNewPacketWithTransport verifies a Packet constructed with a custom
+// Transport uses it instead of the default UDP4 transport.
+func TestNewPacketWithTransport(t *testing.T) {
+	mem := &MemoryTransport{}
+	pk := NewPacketWithTransport(mem)
+
+	pk.writeHeader()
+	if err := pk.WriteMAC("00:11:22:33:44:55"); err != nil {
+		t.Fatalf("WriteMAC() unexpected error: %v", err)
+	}
+
+	if err := pk.SendUDP("255.255.255.255", "9"); err != nil {
+		t.Fatalf("SendUDP() unexpected error: %v", err)
+	}
+
+	if len(mem.Sent) != 1 {
+		t.Fatalf("Sent length = %d, want 1", len(mem.Sent))
+	}
+}
+
+// TestSendRawEthernet verifies SendRawEthernet addresses its configured
+// Transport by MAC instead of "host:port", as RawEthernetTransport expects.
+func TestSendRawEthernet(t *testing.T) {
+	mem := &MemoryTransport{}
+	pk := NewPacketWithTransport(mem)
+
+	pk.writeHeader()
+	if err := pk.WriteMAC("00:11:22:33:44:55"); err != nil {
+		t.Fatalf("WriteMAC() unexpected error: %v", err)
+	}
+
+	if err := pk.SendRawEthernet("FF:FF:FF:FF:FF:FF"); err != nil {
+		t.Fatalf("SendRawEthernet() unexpected error: %v", err)
+	}
+
+	sent := mem.Last()
+	if sent.Dest != "FF:FF:FF:FF:FF:FF" {
+		t.Errorf("Dest = %q, want %q", sent.Dest, "FF:FF:FF:FF:FF:FF")
+	}
+	if len(sent.Payload) != 102 {
+		t.Errorf("payload length = %d, want 102", len(sent.Payload))
+	}
+}
+
+// TestSendRawEthernetInvalidMAC verifies SendRawEthernet rejects a malformed
+// destination MAC before touching the transport.
+func TestSendRawEthernetInvalidMAC(t *testing.T) {
+	mem := &MemoryTransport{}
+	pk := NewPacketWithTransport(mem)
+	pk.writeHeader()
+
+	if err := pk.SendRawEthernet("not-a-mac"); err == nil {
+		t.Error("SendRawEthernet() expected error for invalid MAC, got nil")
+	}
+	if len(mem.Sent) != 0 {
+		t.Errorf("Sent length = %d, want 0 after rejected send", len(mem.Sent))
+	}
+}
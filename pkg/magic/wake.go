@@ -0,0 +1,230 @@
+package wakeonlan
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"strconv"
+	"time"
+)
+
+// Probe checks whether a target host has become reachable. Implementations
+// are used by WakeAndWait to decide when a woken machine is actually up.
+type Probe interface {
+	// Probe returns nil once the host is reachable, or an error describing
+	// why it currently is not.
+	Probe(ctx context.Context) error
+	// String describes the probe, for use in WakeAndWait's error messages.
+	String() string
+}
+
+// TCPProbe succeeds as soon as any one of Ports accepts a TCP connection on
+// Host, using a Happy-Eyeballs-style race: all ports are dialed concurrently
+// and the rest are canceled once one succeeds.
+type TCPProbe struct {
+	Host  string
+	Ports []int
+}
+
+// Probe implements Probe.
+func (p TCPProbe) Probe(ctx context.Context) error {
+	if len(p.Ports) == 0 {
+		return fmt.Errorf("TCPProbe %s: no ports configured", p.Host)
+	}
+
+	raceCtx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	type result struct {
+		port int
+		err  error
+	}
+
+	results := make(chan result, len(p.Ports))
+	var dialer net.Dialer
+	for _, port := range p.Ports {
+		go func(port int) {
+			addr := net.JoinHostPort(p.Host, strconv.Itoa(port))
+			conn, err := dialer.DialContext(raceCtx, "tcp", addr)
+			if err == nil {
+				conn.Close()
+			}
+			results <- result{port: port, err: err}
+		}(port)
+	}
+
+	var errs []error
+	for range p.Ports {
+		r := <-results
+		if r.err == nil {
+			cancel()
+			return nil
+		}
+		errs = append(errs, fmt.Errorf("port %d: %w", r.port, r.err))
+	}
+
+	return fmt.Errorf("all ports unreachable: %w", errors.Join(errs...))
+}
+
+// String implements fmt.Stringer.
+func (p TCPProbe) String() string {
+	return fmt.Sprintf("TCP probe of %s ports %v", p.Host, p.Ports)
+}
+
+// ICMPProbe succeeds once Host answers an ICMP echo request. Opening a raw
+// ICMP socket typically requires elevated privileges (CAP_NET_RAW or root).
+type ICMPProbe struct {
+	Host string
+	// Timeout bounds a single echo round-trip. Defaults to 2 seconds.
+	Timeout time.Duration
+}
+
+// Probe implements Probe.
+func (p ICMPProbe) Probe(ctx context.Context) error {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = 2 * time.Second
+	}
+	if deadline, ok := ctx.Deadline(); ok {
+		if remaining := time.Until(deadline); remaining < timeout {
+			timeout = remaining
+		}
+	}
+
+	conn, err := net.Dial("ip4:icmp", p.Host)
+	if err != nil {
+		return fmt.Errorf("ICMPProbe %s: dial: %w", p.Host, err)
+	}
+	defer conn.Close()
+
+	if err := conn.SetDeadline(time.Now().Add(timeout)); err != nil {
+		return fmt.Errorf("ICMPProbe %s: set deadline: %w", p.Host, err)
+	}
+
+	if _, err := conn.Write(icmpEchoRequest(uint16(os.Getpid()), 1)); err != nil {
+		return fmt.Errorf("ICMPProbe %s: write echo request: %w", p.Host, err)
+	}
+
+	reply := make([]byte, 512)
+	if _, err := conn.Read(reply); err != nil {
+		return fmt.Errorf("ICMPProbe %s: no echo reply: %w", p.Host, err)
+	}
+
+	return nil
+}
+
+// String implements fmt.Stringer.
+func (p ICMPProbe) String() string {
+	return fmt.Sprintf("ICMP probe of %s", p.Host)
+}
+
+// icmpEchoRequest builds a minimal ICMPv4 echo request with id and seq.
+func icmpEchoRequest(id, seq uint16) []byte {
+	const typeEchoRequest = 8
+	msg := make([]byte, 8)
+	msg[0] = typeEchoRequest
+	msg[1] = 0 // code
+	binary.BigEndian.PutUint16(msg[4:6], id)
+	binary.BigEndian.PutUint16(msg[6:8], seq)
+	binary.BigEndian.PutUint16(msg[2:4], icmpChecksum(msg))
+	return msg
+}
+
+// icmpChecksum computes the ICMP checksum (RFC 792) of b, assuming the
+// checksum field itself (bytes 2:4) is zeroed.
+func icmpChecksum(b []byte) uint16 {
+	var sum uint32
+	for i := 0; i+1 < len(b); i += 2 {
+		sum += uint32(b[i])<<8 | uint32(b[i+1])
+	}
+	if len(b)%2 == 1 {
+		sum += uint32(b[len(b)-1]) << 8
+	}
+	for sum>>16 > 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	return ^uint16(sum)
+}
+
+// WakeOptions configures WakeAndWait.
+type WakeOptions struct {
+	// MAC, Password, IP, and Port are passed through to SendMagic exactly
+	// as-is; see SendMagic for their semantics and defaults.
+	MAC      string
+	Password string
+	IP       string
+	Port     string
+
+	// Probe determines when the target is considered awake. If nil,
+	// WakeAndWait returns immediately after the first magic packet is sent.
+	Probe Probe
+	// Timeout bounds the whole wait, including retries. Zero means no
+	// timeout beyond ctx's own deadline, if any.
+	Timeout time.Duration
+	// Interval is how long to wait after a failed probe attempt before
+	// resending the magic packet and probing again. Defaults to 5 seconds.
+	Interval time.Duration
+	// Retries is how many additional magic packets to send, spaced
+	// Interval apart, while waiting for Probe to succeed. Zero means the
+	// initial packet is never resent.
+	Retries int
+}
+
+// WakeResult describes a successful WakeAndWait call.
+type WakeResult struct {
+	// Elapsed is how long it took from the initial magic packet until
+	// Probe reported the host as reachable.
+	Elapsed time.Duration
+}
+
+// WakeAndWait sends a magic packet per opts and then, if opts.Probe is set,
+// polls it every opts.Interval until it succeeds, resending the magic packet
+// up to opts.Retries times while it waits. It returns once the probe
+// succeeds, ctx or opts.Timeout expires, or the retries are exhausted.
+func WakeAndWait(ctx context.Context, opts WakeOptions) (*WakeResult, error) {
+	interval := opts.Interval
+	if interval <= 0 {
+		interval = 5 * time.Second
+	}
+
+	if opts.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, opts.Timeout)
+		defer cancel()
+	}
+
+	start := time.Now()
+
+	if err := SendMagic(opts.MAC, opts.Password, opts.IP, opts.Port); err != nil {
+		return nil, fmt.Errorf("send magic packet: %w", err)
+	}
+
+	if opts.Probe == nil {
+		return &WakeResult{Elapsed: time.Since(start)}, nil
+	}
+
+	for attempt := 0; ; attempt++ {
+		if err := opts.Probe.Probe(ctx); err == nil {
+			return &WakeResult{Elapsed: time.Since(start)}, nil
+		}
+
+		if attempt >= opts.Retries {
+			return nil, fmt.Errorf("wake %q: %s did not succeed after %d retries (%s elapsed)",
+				opts.MAC, opts.Probe, opts.Retries, time.Since(start))
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("wake %q: %s timed out after %s: %w",
+				opts.MAC, opts.Probe, time.Since(start), ctx.Err())
+		case <-time.After(interval):
+		}
+
+		if err := SendMagic(opts.MAC, opts.Password, opts.IP, opts.Port); err != nil {
+			return nil, fmt.Errorf("resend magic packet: %w", err)
+		}
+	}
+}
@@ -0,0 +1,203 @@
+package wakeonlan
+
+import (
+	"context"
+	"net"
+	"strconv"
+	"testing"
+	"time"
+)
+
+// TestTCPProbeSucceedsOnOpenPort verifies TCPProbe succeeds when at least
+// one of its ports is accepting connections.
+func TestTCPProbeSucceedsOnOpenPort(t *testing.T) {
+	ln, err := net.Listen("tcp", "127.0.0.1:0")
+	if err != nil {
+		t.Fatalf("listen: %v", err)
+	}
+	defer ln.Close()
+
+	go func() {
+		for {
+			conn, err := ln.Accept()
+			if err != nil {
+				return
+			}
+			conn.Close()
+		}
+	}()
+
+	host, portStr, err := net.SplitHostPort(ln.Addr().String())
+	if err != nil {
+		t.Fatalf("SplitHostPort: %v", err)
+	}
+	openPort, err := strconv.Atoi(portStr)
+	if err != nil {
+		t.Fatalf("parse port: %v", err)
+	}
+
+	probe := TCPProbe{Host: host, Ports: []int{1, openPort}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := probe.Probe(ctx); err != nil {
+		t.Errorf("Probe() unexpected error: %v", err)
+	}
+}
+
+// TestTCPProbeFailsWhenNoPortOpen verifies TCPProbe errors when every port
+// is unreachable.
+func TestTCPProbeFailsWhenNoPortOpen(t *testing.T) {
+	probe := TCPProbe{Host: "127.0.0.1", Ports: []int{1}}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if err := probe.Probe(ctx); err == nil {
+		t.Error("Probe() expected error, got nil")
+	}
+}
+
+// TestTCPProbeNoPorts verifies TCPProbe rejects an empty port list.
+func TestTCPProbeNoPorts(t *testing.T) {
+	probe := TCPProbe{Host: "127.0.0.1"}
+	if err := probe.Probe(context.Background()); err == nil {
+		t.Error("Probe() expected error for empty Ports, got nil")
+	}
+}
+
+// TestICMPChecksum verifies the checksum of a known echo request.
+func TestICMPChecksum(t *testing.T) {
+	msg := icmpEchoRequest(1234, 1)
+	if len(msg) != 8 {
+		t.Fatalf("icmpEchoRequest() length = %d, want 8", len(msg))
+	}
+
+	// A correctly-checksummed ICMP message sums to 0xFFFF (all bits set)
+	// when the checksum itself is included in the verification sum.
+	var sum uint32
+	for i := 0; i+1 < len(msg); i += 2 {
+		sum += uint32(msg[i])<<8 | uint32(msg[i+1])
+	}
+	for sum>>16 > 0 {
+		sum = (sum & 0xffff) + (sum >> 16)
+	}
+	if sum != 0xffff {
+		t.Errorf("checksum verification sum = 0x%04X, want 0xFFFF", sum)
+	}
+}
+
+// TestWakeAndWaitNoProbe verifies WakeAndWait returns immediately after
+// sending the magic packet when no Probe is configured.
+func TestWakeAndWaitNoProbe(t *testing.T) {
+	opts := WakeOptions{
+		MAC:  "00:11:22:33:44:55",
+		IP:   "255.255.255.255",
+		Port: "9",
+	}
+
+	result, err := WakeAndWait(context.Background(), opts)
+	if err != nil {
+		t.Fatalf("WakeAndWait() unexpected error: %v", err)
+	}
+	if result == nil {
+		t.Fatal("WakeAndWait() returned nil result")
+	}
+}
+
+// TestWakeAndWaitProbeSucceedsImmediately verifies WakeAndWait returns
+// success as soon as the probe passes, without waiting for Interval.
+func TestWakeAndWaitProbeSucceedsImmediately(t *testing.T) {
+	opts := WakeOptions{
+		MAC:      "00:11:22:33:44:55",
+		IP:       "255.255.255.255",
+		Port:     "9",
+		Probe:    alwaysSucceedsProbe{},
+		Interval: time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	result, err := WakeAndWait(ctx, opts)
+	if err != nil {
+		t.Fatalf("WakeAndWait() unexpected error: %v", err)
+	}
+	if result.Elapsed > time.Second {
+		t.Errorf("Elapsed = %v, want well under the Interval", result.Elapsed)
+	}
+}
+
+// TestWakeAndWaitExhaustsRetries verifies WakeAndWait gives up with a
+// descriptive error once Retries is exhausted.
+func TestWakeAndWaitExhaustsRetries(t *testing.T) {
+	opts := WakeOptions{
+		MAC:      "00:11:22:33:44:55",
+		IP:       "255.255.255.255",
+		Port:     "9",
+		Probe:    alwaysFailsProbe{},
+		Interval: time.Millisecond,
+		Retries:  2,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 2*time.Second)
+	defer cancel()
+
+	if _, err := WakeAndWait(ctx, opts); err == nil {
+		t.Error("WakeAndWait() expected error after exhausting retries, got nil")
+	}
+}
+
+// TestWakeAndWaitZeroRetriesStopsAfterFirstFailure verifies the documented
+// zero-value behavior: with Retries left unset, WakeAndWait gives up as soon
+// as the first probe attempt fails, instead of resending forever.
+func TestWakeAndWaitZeroRetriesStopsAfterFirstFailure(t *testing.T) {
+	probe := &countingProbe{}
+	opts := WakeOptions{
+		MAC:      "00:11:22:33:44:55",
+		IP:       "255.255.255.255",
+		Port:     "9",
+		Probe:    probe,
+		Interval: time.Hour,
+	}
+
+	ctx, cancel := context.WithTimeout(context.Background(), 500*time.Millisecond)
+	defer cancel()
+
+	start := time.Now()
+	_, err := WakeAndWait(ctx, opts)
+	if err == nil {
+		t.Fatal("WakeAndWait() expected error after a single failed probe, got nil")
+	}
+	if elapsed := time.Since(start); elapsed > 250*time.Millisecond {
+		t.Errorf("WakeAndWait() took %v, want well under the Interval (no resend expected)", elapsed)
+	}
+	if probe.calls != 1 {
+		t.Errorf("probe called %d times, want exactly 1", probe.calls)
+	}
+}
+
+type countingProbe struct{ calls int }
+
+func (p *countingProbe) Probe(ctx context.Context) error {
+	p.calls++
+	return errAlwaysFails
+}
+func (p *countingProbe) String() string { return "counting probe" }
+
+type alwaysSucceedsProbe struct{}
+
+func (alwaysSucceedsProbe) Probe(ctx context.Context) error { return nil }
+func (alwaysSucceedsProbe) String() string                  { return "always-succeeds probe" }
+
+type alwaysFailsProbe struct{}
+
+func (alwaysFailsProbe) Probe(ctx context.Context) error { return errAlwaysFails }
+func (alwaysFailsProbe) String() string                  { return "always-fails probe" }
+
+var errAlwaysFails = &probeError{"probe never succeeds"}
+
+type probeError struct{ msg string }
+
+func (e *probeError) Error() string { return e.msg }